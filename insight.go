@@ -22,14 +22,25 @@ type bucket struct {
 	count float64
 }
 
+// floating-point values within this relative distance share a bucket
+const nearEpsilon = 1e-6
+
+type sample struct {
+	value float64
+	float bool
+}
+
 type S struct {
-	samp  chan int
+	samp  chan sample
 	stop  chan struct{}
 	bins  int
 	beta  float64
 	lock  sync.RWMutex
 	hist  []bucket
 	total bucket
+	min   float64
+	max   float64
+	float bool
 }
 
 // New returns a new object with the specified number of bins and decay time constant.
@@ -48,27 +59,54 @@ func New(bins int, t time.Duration) *S {
 	beta = math.Pow(.5, float64(tick)/float64(t))
 
 	s := &S{
-		samp: make(chan int, 1000),
+		samp: make(chan sample, 1000),
 		stop: make(chan struct{}),
 		hist: make([]bucket, 0, bins*5/4),
 		bins: bins,
 		beta: beta,
+		min:  math.Inf(1),
+		max:  math.Inf(-1),
 	}
 	go s.work(tick)
 	return s
 }
 
 // Close stops any and all goroutines maintaining the object behind the curtain.
+// values added after Close are discarded. to reuse an object, use Reset instead.
 func (s *S) Close() {
 	close(s.stop)
 }
 
+// Reset discards all accumulated data, including the observed min + max.
+// the object remains running, and may continue to be used.
+func (s *S) Reset() {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.hist = s.hist[:0]
+	s.total = bucket{}
+	s.min = math.Inf(1)
+	s.max = math.Inf(-1)
+	s.float = false
+}
+
 // Add adds a new value.
 func (s *S) Add(dt int) {
+	s.push(sample{value: float64(dt)})
+}
+
+// AddFloat adds a new floating-point value.
+// NaN and infinite values are ignored.
+func (s *S) AddFloat(v float64) {
+	s.push(sample{value: v, float: true})
+}
+
+func (s *S) push(v sample) {
 	// if the channel buffer is full, drop the value.
 	// we're looking for insight, not exact values
 	select {
-	case s.samp <- dt:
+	case s.samp <- v:
 		break
 	default:
 		break
@@ -111,6 +149,58 @@ func (s *S) Mean() float64 {
 	return s.total.value / s.total.count
 }
 
+// Merge folds the accumulated data from other into s, so that s reflects
+// the combined stream. the bins and decay of s are retained; other is unchanged.
+func (s *S) Merge(other *S) {
+
+	if s == other {
+		return
+	}
+
+	other.lock.RLock()
+	hist := make([]bucket, len(other.hist))
+	copy(hist, other.hist)
+	total := other.total
+	min, max := other.min, other.max
+	float := other.float
+	other.lock.RUnlock()
+
+	s.lock.Lock()
+	s.total.count += total.count
+	s.total.value += total.value
+	s.min = math.Min(s.min, min)
+	s.max = math.Max(s.max, max)
+	s.float = s.float || float
+	s.hist = mergeHist(s.hist, hist)
+	s.lock.Unlock()
+
+	s.reduce()
+}
+
+// Min returns the smallest value seen.
+func (s *S) Min() float64 {
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if math.IsInf(s.min, 1) {
+		return 0
+	}
+	return s.min
+}
+
+// Max returns the largest value seen.
+func (s *S) Max() float64 {
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if math.IsInf(s.max, -1) {
+		return 0
+	}
+	return s.max
+}
+
 func (s *S) String() string {
 
 	out := ""
@@ -132,7 +222,11 @@ func (s *S) String() string {
 	scale := 80.0 / max
 
 	for _, b := range s.hist {
-		out += fmt.Sprintf("%8d ", int(b.value))
+		if s.float {
+			out += fmt.Sprintf("%8.4g ", b.value)
+		} else {
+			out += fmt.Sprintf("%8d ", int(b.value))
+		}
 		len := int(b.count * scale)
 		out += strings.Repeat("#", len)
 		out += "\n"
@@ -151,7 +245,7 @@ func (s *S) work(tick time.Duration) {
 			tock.Stop()
 			return
 		case v := <-s.samp:
-			s.add(v)
+			s.addValue(v.value, v.float)
 			s.maybereduce()
 		case <-tock.C:
 			s.decay()
@@ -174,25 +268,54 @@ func (s *S) decay() {
 }
 
 func (s *S) add(v int) {
+	s.addValue(float64(v), false)
+}
+
+func (s *S) addFloat(v float64) {
+	s.addValue(v, true)
+}
+
+func (s *S) addValue(v float64, float bool) {
+
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return
+	}
 
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	if float {
+		s.float = true
+	}
+
 	s.total.count++
-	s.total.value += float64(v)
+	s.total.value += v
+
+	if v < s.min {
+		s.min = v
+	}
+	if v > s.max {
+		s.max = v
+	}
 
 	for i, _ := range s.hist {
 		b := &s.hist[i]
+		bv := b.value
 
-		if v == int(b.value+.5) {
+		if !float {
+			// integers match the bucket they round to
+			bv = float64(int(b.value + .5))
+		}
+
+		if v == bv || float && near(v, bv) {
 			// add to matching bucket
 			b.count++
 			return
 		}
 
-		if v < int(b.value+.5) {
+		if v < bv {
 			// insert new bucket
-			nb := bucket{value: float64(v), count: 1}
+			nb := bucket{value: v, count: 1}
 			s.hist = append(s.hist, bucket{})
 			copy(s.hist[i+1:], s.hist[i:])
 			s.hist[i] = nb
@@ -201,13 +324,17 @@ func (s *S) add(v int) {
 	}
 
 	// insert at end
-	s.hist = append(s.hist, bucket{value: float64(v), count: 1})
+	s.hist = append(s.hist, bucket{value: v, count: 1})
 }
 
 func (s *S) maybereduce() {
 
+	s.lock.RLock()
+	n := len(s.hist)
+	s.lock.RUnlock()
+
 	// temporarily allow some overage
-	if len(s.hist) > s.bins+s.bins/5 {
+	if n > s.bins+s.bins/5 {
 		s.reduce()
 	}
 }
@@ -248,7 +375,7 @@ func (s *S) closest() int {
 	ci := 0
 	cv := s.hist[1].value - s.hist[0].value
 
-	for i := 1; i < s.bins-1; i++ {
+	for i := 1; i < len(s.hist)-1; i++ {
 		d := s.hist[i+1].value - s.hist[i].value
 
 		if d < cv {
@@ -266,3 +393,34 @@ func (s *S) merged(i int) bucket {
 	a := s.hist[i].count*s.hist[i].value + s.hist[i+1].count*s.hist[i+1].value
 	return bucket{count: c, value: a / c}
 }
+
+// merge two sorted histograms, summing buckets of (nearly) equal value
+func mergeHist(a, b []bucket) []bucket {
+
+	res := make([]bucket, 0, len(a)+len(b))
+	i, j := 0, 0
+
+	for i < len(a) && j < len(b) {
+		switch {
+		case near(a[i].value, b[j].value):
+			res = append(res, bucket{value: a[i].value, count: a[i].count + b[j].count})
+			i++
+			j++
+		case a[i].value < b[j].value:
+			res = append(res, a[i])
+			i++
+		default:
+			res = append(res, b[j])
+			j++
+		}
+	}
+
+	res = append(res, a[i:]...)
+	res = append(res, b[j:]...)
+	return res
+}
+
+// are the values close enough to share a bucket?
+func near(a, b float64) bool {
+	return math.Abs(a-b) <= nearEpsilon*math.Max(math.Abs(a), math.Abs(b))
+}
@@ -9,6 +9,7 @@ package streaminginsight
 
 import (
 	//"fmt"
+	"math"
 	"math/rand"
 	"testing"
 	"time"
@@ -68,3 +69,218 @@ func TestSS2(t *testing.T) {
 	//fmt.Printf("m %f 95 %f 99 %f\n", s.Mean(), s.Percentile(95), s.Percentile(99))
 
 }
+
+func TestMerge(t *testing.T) {
+
+	a := New(100, time.Second)
+	a.Close()
+	b := New(100, time.Second)
+	b.Close()
+	c := New(100, time.Second)
+	c.Close()
+
+	for i := 0; i < 10000; i++ {
+		r := rand.Intn(100)
+		a.add(r)
+		c.add(r)
+		r = rand.Intn(100) + 100
+		b.add(r)
+		c.add(r)
+	}
+	a.reduce()
+	b.reduce()
+	c.reduce()
+
+	a.Merge(b)
+
+	if len(a.hist) > a.bins {
+		t.Fail()
+	}
+	if d := a.Mean() - c.Mean(); d < -1 || d > 1 {
+		t.Fail()
+	}
+	if d := a.Percentile(95) - c.Percentile(95); d < -4 || d > 4 {
+		t.Fail()
+	}
+}
+
+func TestMergeBins(t *testing.T) {
+
+	// destination config wins: a larger source is reduced to fit
+	a := New(50, time.Second)
+	a.Close()
+	b := New(200, time.Second)
+	b.Close()
+	c := New(50, time.Second)
+	c.Close()
+
+	for i := 0; i < 10000; i++ {
+		r := rand.Intn(100)
+		a.add(r)
+		c.add(r)
+		r = rand.Intn(300) + 100
+		b.add(r)
+		c.add(r)
+	}
+	a.reduce()
+	b.reduce()
+	c.reduce()
+
+	a.Merge(b)
+
+	if len(a.hist) > a.bins {
+		t.Fail()
+	}
+	for _, pct := range []float64{25, 50, 75, 95} {
+		if d := a.Percentile(pct) - c.Percentile(pct); d < -2 || d > 2 {
+			t.Fail()
+		}
+	}
+}
+
+func TestMergeOverlap(t *testing.T) {
+
+	a := New(100, time.Second)
+	a.Close()
+	b := New(100, time.Second)
+	b.Close()
+
+	for i := 0; i < 10; i++ {
+		a.add(i)
+		b.add(i)
+		b.add(i)
+	}
+
+	a.Merge(b)
+
+	if len(a.hist) != 10 {
+		t.Fail()
+	}
+	for _, h := range a.hist {
+		if h.count != 3 {
+			t.Fail()
+		}
+	}
+	if a.total.count != 30 || a.Mean() != 4.5 {
+		t.Fail()
+	}
+}
+
+func TestFloat(t *testing.T) {
+
+	s := New(100, time.Second)
+	s.Close()
+
+	s.addFloat(0.25)
+	s.addFloat(1.75)
+	for i := 0; i < 10000; i++ {
+		r := rand.Float64() + 0.5
+		s.addFloat(r)
+	}
+	s.reduce()
+
+	m := s.Mean()
+	nf := s.Percentile(95)
+
+	if s.Min() != 0.25 || s.Max() != 1.75 {
+		t.Fail()
+	}
+	if m < .98 || m > 1.02 {
+		t.Fail()
+	}
+	if nf < 1.42 || nf > 1.48 {
+		t.Fail()
+	}
+
+	for i := 0; i < 1000; i++ {
+		s.decay()
+	}
+	if s.Min() != 0.25 || s.Max() != 1.75 {
+		t.Fail()
+	}
+
+	o := New(100, time.Second)
+	o.Close()
+	o.addFloat(0.125)
+	o.addFloat(1.0)
+
+	s.Merge(o)
+	if s.Min() != 0.125 || s.Max() != 1.75 {
+		t.Fail()
+	}
+	o.Merge(s)
+	if o.Min() != 0.125 || o.Max() != 1.75 {
+		t.Fail()
+	}
+}
+
+func TestNonFinite(t *testing.T) {
+
+	s := New(100, time.Second)
+	s.Close()
+
+	s.addFloat(1)
+	s.addFloat(math.NaN())
+	s.addFloat(math.Inf(1))
+	s.addFloat(math.Inf(-1))
+	s.addFloat(3)
+
+	if len(s.hist) != 2 || s.Mean() != 2 || s.Min() != 1 || s.Max() != 3 {
+		t.Fail()
+	}
+}
+
+func TestInteger(t *testing.T) {
+
+	s := New(100, time.Second)
+	s.Close()
+
+	s.hist = []bucket{{value: 1.3, count: 1}, {value: 2, count: 1}}
+	s.add(1)
+
+	// integers join the bucket they round to
+	if len(s.hist) != 2 || s.hist[0].count != 2 {
+		t.Fail()
+	}
+
+	s.addFloat(1)
+	if len(s.hist) != 3 || s.hist[0].value != 1 {
+		t.Fail()
+	}
+}
+
+func TestReset(t *testing.T) {
+
+	s := New(100, time.Second)
+	defer s.Close()
+
+	s.AddFloat(1.5)
+	s.AddFloat(2.5)
+	waitFor(s, 2.5)
+
+	if s.Min() != 1.5 || s.Max() != 2.5 || s.Mean() != 2 {
+		t.Fail()
+	}
+
+	s.Reset()
+	if s.Min() != 0 || s.Max() != 0 || s.Mean() != 0 || s.Percentile(50) != 0 {
+		t.Fail()
+	}
+
+	// still running after Reset
+	s.AddFloat(0.5)
+	s.AddFloat(0.75)
+	waitFor(s, 0.75)
+
+	if s.Min() != 0.5 || s.Max() != 0.75 || s.Mean() != 0.625 {
+		t.Fail()
+	}
+}
+
+// wait for the worker to process samples
+func waitFor(s *S, max float64) {
+
+	for i := 0; i < 100 && s.Max() != max; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+}